@@ -0,0 +1,71 @@
+package spx_backend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"golang.org/x/sync/singleflight"
+)
+
+// CollectionCache caches describe-collection results so concurrent lookups
+// for the same collection don't each re-issue DescribeCollection against
+// Milvus. Callers that miss the cache share a single in-flight fetch via
+// singleflight.
+type CollectionCache struct {
+	mu          sync.RWMutex
+	collections map[string]*entity.Collection
+	group       singleflight.Group
+}
+
+// NewCollectionCache returns an empty CollectionCache.
+func NewCollectionCache() *CollectionCache {
+	return &CollectionCache{
+		collections: make(map[string]*entity.Collection),
+	}
+}
+
+// GetCollection returns the cached collection for name, fetching it with
+// fetcher on a cache miss. Concurrent misses for the same name are
+// coalesced into a single call to fetcher.
+func (c *CollectionCache) GetCollection(ctx context.Context, name string, fetcher func(ctx context.Context, name string) (*entity.Collection, error)) (*entity.Collection, error) {
+	c.mu.RLock()
+	coll, ok := c.collections[name]
+	c.mu.RUnlock()
+	if ok {
+		return coll, nil
+	}
+
+	v, err, _ := c.group.Do(name, func() (interface{}, error) {
+		coll, err := fetcher(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.collections[name] = coll
+		c.mu.Unlock()
+
+		return coll, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*entity.Collection), nil
+}
+
+// Put inserts coll into the cache under name, overwriting any existing
+// entry. Used to seed the cache right after a collection is created.
+func (c *CollectionCache) Put(name string, coll *entity.Collection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.collections[name] = coll
+}
+
+// Reset clears all cached collections, e.g. when switching environments.
+func (c *CollectionCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.collections = make(map[string]*entity.Collection)
+}
@@ -0,0 +1,17 @@
+package spx_backend
+
+// CollectionOptions controls how a collection is created and loaded:
+// sharding at create time, and replica/resource-group placement at load
+// time, so deployments can pin collections onto dedicated query-node
+// resource groups and scale read replicas without code changes.
+type CollectionOptions struct {
+	ShardsNum      int32
+	ReplicaNumber  int32
+	ResourceGroups []string
+	Properties     map[string]string
+
+	// EnableSparseVector adds a sparse vector field to the asset collection
+	// schema alongside the dense "vector" field, so assets can be retrieved
+	// with HybridSearchAssets as well as SearchAssets.
+	EnableSparseVector bool
+}
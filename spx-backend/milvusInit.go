@@ -2,21 +2,20 @@ package spx_backend
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
-	"log"
 )
 
-func NewCollection() {
-	// connect to  milvus
-	ctx := context.Background()
-	cli, err := client.NewGrpcClient(ctx, "localhost:19530")
-	if err != nil {
-		log.Fatalf("Failed to connect to Milvus: %v", err)
-	}
-	defer cli.Close()
+const (
+	assetCollectionName = "asset"
+	vectorDim           = 256
+)
 
-	// define fields
+// assetSchema builds the "asset" collection schema, including the sparse
+// vector field when opts.EnableSparseVector is set.
+func assetSchema(opts CollectionOptions) *entity.Schema {
 	idField := &entity.Field{
 		Name:       "id",
 		DataType:   entity.FieldTypeInt64,
@@ -28,7 +27,7 @@ func NewCollection() {
 		Name:     "vector",
 		DataType: entity.FieldTypeFloatVector,
 		TypeParams: map[string]string{
-			"dim": "256",
+			"dim": fmt.Sprintf("%d", vectorDim),
 		},
 	}
 
@@ -40,15 +39,54 @@ func NewCollection() {
 		},
 	}
 
-	// define collection schema
-	schema := &entity.Schema{
-		CollectionName: "asset",
-		Fields:         []*entity.Field{idField, vectorField, assetIDField},
+	fields := []*entity.Field{idField, vectorField, assetIDField}
+	if opts.EnableSparseVector {
+		fields = append(fields, &entity.Field{
+			Name:     sparseVectorFieldName,
+			DataType: entity.FieldTypeSparseVector,
+		})
+	}
+
+	return &entity.Schema{
+		CollectionName: assetCollectionName,
+		Fields:         fields,
+	}
+}
+
+// NewCollection bootstraps, indexes and loads the "asset" collection used
+// to store asset embeddings. It is a method on MilvusClient so callers
+// reuse the long-lived connection instead of dialing Milvus per call. opts
+// controls sharding/properties at create time and replica/resource-group
+// placement at load time; idx controls the vector index built before the
+// collection is loaded. Bootstrap is idempotent: see EnsureCollection.
+func (m *MilvusClient) NewCollection(ctx context.Context, opts CollectionOptions, idx IndexParams) error {
+	schema := assetSchema(opts)
+
+	if err := m.EnsureCollection(ctx, schema, opts); err != nil {
+		return err
 	}
 
-	// create collection
-	err = cli.CreateCollection(ctx, schema, 1)
-	if err != nil {
-		log.Fatalf("Failed to create collection: %v", err)
+	if err := m.EnsureIndex(ctx, idx); err != nil {
+		return err
 	}
+
+	if opts.EnableSparseVector {
+		if err := m.EnsureSparseIndex(ctx); err != nil {
+			return err
+		}
+	}
+
+	var loadOpts []client.LoadCollectionOption
+	if opts.ReplicaNumber > 0 {
+		loadOpts = append(loadOpts, client.WithReplicaNumber(opts.ReplicaNumber))
+	}
+	if len(opts.ResourceGroups) > 0 {
+		loadOpts = append(loadOpts, client.WithResourceGroups(opts.ResourceGroups))
+	}
+
+	if err := m.cli.LoadCollection(ctx, schema.CollectionName, false, loadOpts...); err != nil {
+		return fmt.Errorf("load asset collection: %w", err)
+	}
+
+	return nil
 }
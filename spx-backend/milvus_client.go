@@ -0,0 +1,86 @@
+package spx_backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"google.golang.org/grpc"
+)
+
+// RetryRateLimit configures the client-side retry/backoff applied to
+// requests that hit Milvus's rate limiter. The field types mirror the
+// SDK's client.RetryRateLimitOption.
+type RetryRateLimit struct {
+	MaxRetry   uint
+	MaxBackoff time.Duration
+}
+
+// MilvusConfig describes how to connect to a Milvus deployment. It mirrors
+// the shape of the Zilliz client.Config so the same values work whether the
+// target is self-hosted Milvus or Zilliz Cloud.
+type MilvusConfig struct {
+	Address        string
+	Username       string
+	Password       string
+	APIKey         string
+	DBName         string
+	EnableTLSAuth  bool
+	DialOptions    []grpc.DialOption
+	RetryRateLimit RetryRateLimit
+}
+
+// MilvusClient is a long-lived wrapper around a Milvus client.Client. It is
+// constructed once at process start and injected into handlers that need to
+// talk to Milvus, instead of dialing a fresh connection per call.
+type MilvusClient struct {
+	cli    client.Client
+	config MilvusConfig
+	cache  *CollectionCache
+
+	// assetMetricType is the metric type of the asset vector index, set by
+	// EnsureIndex and used by SearchAssets so queries match the index.
+	assetMetricType entity.MetricType
+}
+
+// NewMilvusClient dials Milvus using cfg and returns a MilvusClient ready to
+// be shared across handlers. Callers own the returned client's lifecycle and
+// should call Close when shutting down.
+func NewMilvusClient(ctx context.Context, cfg MilvusConfig) (*MilvusClient, error) {
+	opts := make([]grpc.DialOption, len(cfg.DialOptions))
+	copy(opts, cfg.DialOptions)
+
+	cli, err := client.NewClient(ctx, client.Config{
+		Address:       cfg.Address,
+		Username:      cfg.Username,
+		Password:      cfg.Password,
+		APIKey:        cfg.APIKey,
+		DBName:        cfg.DBName,
+		EnableTLSAuth: cfg.EnableTLSAuth,
+		DialOptions:   opts,
+		RetryRateLimit: &client.RetryRateLimitOption{
+			MaxRetry:   cfg.RetryRateLimit.MaxRetry,
+			MaxBackoff: cfg.RetryRateLimit.MaxBackoff,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connect to milvus at %s: %w", cfg.Address, err)
+	}
+
+	return &MilvusClient{cli: cli, config: cfg, cache: NewCollectionCache()}, nil
+}
+
+// DescribeCollection returns the schema and metadata for name, preferring
+// the CollectionCache over issuing a DescribeCollection call to Milvus.
+func (m *MilvusClient) DescribeCollection(ctx context.Context, name string) (*entity.Collection, error) {
+	return m.cache.GetCollection(ctx, name, func(ctx context.Context, name string) (*entity.Collection, error) {
+		return m.cli.DescribeCollection(ctx, name)
+	})
+}
+
+// Close releases the underlying connection to Milvus.
+func (m *MilvusClient) Close() error {
+	return m.cli.Close()
+}
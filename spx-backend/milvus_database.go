@@ -0,0 +1,43 @@
+package spx_backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+)
+
+// EnsureDatabase creates the database name if it does not already exist,
+// applying opts (e.g. client.WithDatabaseProperty("database.diskQuota.mb",
+// "1024")) as database properties. This lets a single Milvus deployment
+// host isolated asset spaces per tenant/project, each with its own quota,
+// instead of one shared default database.
+func (m *MilvusClient) EnsureDatabase(ctx context.Context, name string, opts ...client.CreateDatabaseOption) error {
+	names, err := m.cli.ListDatabases(ctx)
+	if err != nil {
+		return fmt.Errorf("list databases: %w", err)
+	}
+
+	for _, db := range names {
+		if db.Name == name {
+			return nil
+		}
+	}
+
+	if err := m.cli.CreateDatabase(ctx, name, opts...); err != nil {
+		return fmt.Errorf("create database %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// UsingDatabase scopes all subsequent collection operations on m to the
+// given database, e.g. to switch a tenant's asset space.
+func (m *MilvusClient) UsingDatabase(ctx context.Context, name string) error {
+	if err := m.cli.UsingDatabase(ctx, name); err != nil {
+		return fmt.Errorf("switch to database %s: %w", name, err)
+	}
+	m.config.DBName = name
+	m.cache.Reset()
+	return nil
+}
@@ -0,0 +1,104 @@
+package spx_backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+const sparseVectorFieldName = "sparse_vector"
+
+// RerankStrategy selects how HybridSearchAssets combines the dense and
+// sparse search results into a single ranking.
+type RerankStrategy interface {
+	reranker() client.Reranker
+}
+
+// RRFRerank combines dense and sparse results with reciprocal rank fusion.
+// The SDK's reciprocal rank fusion coefficient is fixed (K=60) and not
+// configurable.
+type RRFRerank struct{}
+
+func (r RRFRerank) reranker() client.Reranker {
+	return client.NewRRFReranker()
+}
+
+// WeightedRerank combines dense and sparse results with a per-branch
+// weight, in the same [dense, sparse] order the AnnSearchRequests are
+// built in.
+type WeightedRerank struct {
+	DenseWeight  float64
+	SparseWeight float64
+}
+
+func (r WeightedRerank) reranker() client.Reranker {
+	return client.NewWeightedReranker([]float64{r.DenseWeight, r.SparseWeight})
+}
+
+// EnsureSparseIndex creates an index on the "sparse_vector" field of the
+// asset collection if one does not already exist. Milvus requires every
+// searched vector field to be indexed, so this must run before
+// HybridSearchAssets is usable on a collection created with
+// EnableSparseVector.
+func (m *MilvusClient) EnsureSparseIndex(ctx context.Context) error {
+	exists, err := indexExists(ctx, m.cli, assetCollectionName, sparseVectorFieldName)
+	if err != nil {
+		return fmt.Errorf("check asset sparse vector index: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	idx := entity.NewGenericIndex("asset_sparse_vector_index", entity.SparseInverted, map[string]string{
+		"drop_ratio_build": "0.2",
+	})
+
+	if err := m.cli.CreateIndex(ctx, assetCollectionName, sparseVectorFieldName, idx, false); err != nil {
+		return fmt.Errorf("create asset sparse vector index: %w", err)
+	}
+
+	return nil
+}
+
+// HybridSearchAssets runs a dense + sparse hybrid search against the asset
+// collection, combining both signals with rerank, so results reflect both
+// semantic similarity (dense) and lexical/tag overlap (sparse).
+func (m *MilvusClient) HybridSearchAssets(ctx context.Context, dense []float32, sparse entity.SparseEmbedding, topK int, rerank RerankStrategy) ([]AssetHit, error) {
+	denseSearchParam, err := entity.NewIndexHNSWSearchParam(searchEf)
+	if err != nil {
+		return nil, fmt.Errorf("build asset dense search params: %w", err)
+	}
+
+	sparseSearchParam, err := entity.NewIndexSparseInvertedSearchParam(1.0)
+	if err != nil {
+		return nil, fmt.Errorf("build asset sparse search params: %w", err)
+	}
+
+	denseReq := client.NewANNSearchRequest("vector", m.assetMetricType, "", []entity.Vector{entity.FloatVector(dense)}, denseSearchParam, topK)
+	sparseReq := client.NewANNSearchRequest(sparseVectorFieldName, entity.IP, "", []entity.Vector{sparse}, sparseSearchParam, topK)
+
+	results, err := m.cli.HybridSearch(ctx, assetCollectionName, nil, topK, []string{"asset_id"},
+		rerank.reranker(), []*client.ANNSearchRequest{denseReq, sparseReq})
+	if err != nil {
+		return nil, fmt.Errorf("hybrid search asset vectors: %w", err)
+	}
+
+	var hits []AssetHit
+	for _, res := range results {
+		assetIDCol, ok := res.Fields.GetColumn("asset_id").(*entity.ColumnVarChar)
+		if !ok {
+			return nil, fmt.Errorf("hybrid search asset vectors: asset_id column missing or wrong type")
+		}
+
+		for i := 0; i < res.ResultCount; i++ {
+			hits = append(hits, AssetHit{
+				AssetID: assetIDCol.Data()[i],
+				Score:   res.Scores[i],
+			})
+		}
+	}
+
+	return hits, nil
+}
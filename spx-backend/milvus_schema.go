@@ -0,0 +1,136 @@
+package spx_backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// ErrSchemaDrift is returned by EnsureCollection when the collection
+// already exists with a schema that conflicts with desired (e.g. a new
+// field, a changed vector dimension, or a primary key change), so an
+// operator can decide whether to drop and recreate it. The SDK has no
+// mechanism to alter an existing collection's fields, so any field-level
+// difference is reported rather than applied.
+type ErrSchemaDrift struct {
+	Collection string
+	Conflict   string
+}
+
+func (e *ErrSchemaDrift) Error() string {
+	return fmt.Sprintf("schema drift on collection %s: %s", e.Collection, e.Conflict)
+}
+
+// EnsureCollection idempotently bootstraps a collection: it creates
+// desired if absent, no-ops if the existing schema already matches, and
+// returns an *ErrSchemaDrift if the existing schema differs from desired
+// in any way, so restarts never silently run against a mismatched
+// collection.
+func (m *MilvusClient) EnsureCollection(ctx context.Context, desired *entity.Schema, opts CollectionOptions) error {
+	has, err := m.cli.HasCollection(ctx, desired.CollectionName)
+	if err != nil {
+		return fmt.Errorf("check collection %s exists: %w", desired.CollectionName, err)
+	}
+
+	if !has {
+		createOpts := make([]client.CreateCollectionOption, 0, len(opts.Properties))
+		for k, v := range opts.Properties {
+			createOpts = append(createOpts, client.WithCollectionProperty(k, v))
+		}
+
+		shardsNum := opts.ShardsNum
+		if shardsNum == 0 {
+			shardsNum = 1
+		}
+
+		if err := m.cli.CreateCollection(ctx, desired, shardsNum, createOpts...); err != nil {
+			return fmt.Errorf("create collection %s: %w", desired.CollectionName, err)
+		}
+
+		coll, err := m.cli.DescribeCollection(ctx, desired.CollectionName)
+		if err != nil {
+			return fmt.Errorf("describe collection %s after create: %w", desired.CollectionName, err)
+		}
+		m.cache.Put(desired.CollectionName, coll)
+
+		return nil
+	}
+
+	existing, err := m.cli.DescribeCollection(ctx, desired.CollectionName)
+	if err != nil {
+		return fmt.Errorf("describe collection %s: %w", desired.CollectionName, err)
+	}
+
+	if err := diffSchema(existing.Schema, desired); err != nil {
+		return err
+	}
+
+	m.cache.Put(desired.CollectionName, existing)
+
+	return nil
+}
+
+// diffSchema compares existing against desired field by field. A matching
+// schema is a no-op; any difference — a new or missing field, a changed
+// type, a changed primary key, or a changed vector dimension — is reported
+// as schema drift, since the SDK offers no way to alter fields on an
+// existing collection.
+func diffSchema(existing, desired *entity.Schema) error {
+	existingFields := make(map[string]*entity.Field, len(existing.Fields))
+	for _, f := range existing.Fields {
+		existingFields[f.Name] = f
+	}
+
+	desiredFields := make(map[string]struct{}, len(desired.Fields))
+	for _, f := range desired.Fields {
+		desiredFields[f.Name] = struct{}{}
+	}
+
+	for name := range existingFields {
+		if _, ok := desiredFields[name]; !ok {
+			return &ErrSchemaDrift{
+				Collection: desired.CollectionName,
+				Conflict:   fmt.Sprintf("field %q was removed", name),
+			}
+		}
+	}
+
+	for _, f := range desired.Fields {
+		existingField, ok := existingFields[f.Name]
+		if !ok {
+			return &ErrSchemaDrift{
+				Collection: desired.CollectionName,
+				Conflict:   fmt.Sprintf("field %q is new; the SDK cannot add fields to an existing collection", f.Name),
+			}
+		}
+
+		if existingField.DataType != f.DataType {
+			return &ErrSchemaDrift{
+				Collection: desired.CollectionName,
+				Conflict:   fmt.Sprintf("field %q changed type from %v to %v", f.Name, existingField.DataType, f.DataType),
+			}
+		}
+
+		if existingField.PrimaryKey != f.PrimaryKey {
+			return &ErrSchemaDrift{
+				Collection: desired.CollectionName,
+				Conflict:   fmt.Sprintf("field %q primary key changed", f.Name),
+			}
+		}
+
+		if isVectorType(f.DataType) && existingField.TypeParams["dim"] != f.TypeParams["dim"] {
+			return &ErrSchemaDrift{
+				Collection: desired.CollectionName,
+				Conflict:   fmt.Sprintf("field %q dimension changed from %s to %s", f.Name, existingField.TypeParams["dim"], f.TypeParams["dim"]),
+			}
+		}
+	}
+
+	return nil
+}
+
+func isVectorType(t entity.FieldType) bool {
+	return t == entity.FieldTypeFloatVector || t == entity.FieldTypeBinaryVector || t == entity.FieldTypeSparseVector
+}
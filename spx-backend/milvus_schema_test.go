@@ -0,0 +1,87 @@
+package spx_backend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+func TestDiffSchema_MatchingIsNoop(t *testing.T) {
+	schema := assetSchema(CollectionOptions{})
+
+	if err := diffSchema(schema, schema); err != nil {
+		t.Fatalf("diffSchema: %v", err)
+	}
+}
+
+func TestDiffSchema_DimMismatchIsDrift(t *testing.T) {
+	existing := assetSchema(CollectionOptions{})
+	desired := assetSchema(CollectionOptions{})
+	desired.Fields[1].TypeParams["dim"] = "768"
+
+	err := diffSchema(existing, desired)
+
+	var drift *ErrSchemaDrift
+	if !errors.As(err, &drift) {
+		t.Fatalf("diffSchema: expected *ErrSchemaDrift, got %v", err)
+	}
+}
+
+func TestDiffSchema_PrimaryKeyChangeIsDrift(t *testing.T) {
+	existing := assetSchema(CollectionOptions{})
+	desired := assetSchema(CollectionOptions{})
+	desired.Fields[0].PrimaryKey = false
+	desired.Fields[2].PrimaryKey = true
+
+	err := diffSchema(existing, desired)
+
+	var drift *ErrSchemaDrift
+	if !errors.As(err, &drift) {
+		t.Fatalf("diffSchema: expected *ErrSchemaDrift, got %v", err)
+	}
+}
+
+func TestDiffSchema_NewVectorFieldIsDrift(t *testing.T) {
+	existing := assetSchema(CollectionOptions{})
+	desired := assetSchema(CollectionOptions{EnableSparseVector: true})
+
+	err := diffSchema(existing, desired)
+
+	var drift *ErrSchemaDrift
+	if !errors.As(err, &drift) {
+		t.Fatalf("diffSchema: expected *ErrSchemaDrift for new vector field, got %v", err)
+	}
+}
+
+func TestDiffSchema_NewScalarFieldIsDrift(t *testing.T) {
+	existing := assetSchema(CollectionOptions{})
+	desired := assetSchema(CollectionOptions{})
+	desired.Fields = append(desired.Fields, &entity.Field{
+		Name:     "tags",
+		DataType: entity.FieldTypeVarChar,
+		TypeParams: map[string]string{
+			"max_length": "256",
+		},
+	})
+
+	err := diffSchema(existing, desired)
+
+	var drift *ErrSchemaDrift
+	if !errors.As(err, &drift) {
+		t.Fatalf("diffSchema: expected *ErrSchemaDrift for new scalar field (SDK cannot alter collection fields), got %v", err)
+	}
+}
+
+func TestDiffSchema_RemovedFieldIsDrift(t *testing.T) {
+	existing := assetSchema(CollectionOptions{})
+	desired := assetSchema(CollectionOptions{})
+	desired.Fields = desired.Fields[:len(desired.Fields)-1]
+
+	err := diffSchema(existing, desired)
+
+	var drift *ErrSchemaDrift
+	if !errors.As(err, &drift) {
+		t.Fatalf("diffSchema: expected *ErrSchemaDrift for removed field, got %v", err)
+	}
+}
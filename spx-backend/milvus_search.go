@@ -0,0 +1,176 @@
+package spx_backend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+const searchEf = 64
+
+// IndexParams describes the vector index to build on the "vector" field of
+// the asset collection.
+type IndexParams struct {
+	MetricType entity.MetricType
+	IndexType  entity.IndexType
+	Params     map[string]string
+}
+
+// AssetVector is a single embedding to insert into the asset collection.
+// SparseVector is only required when the collection was created with
+// CollectionOptions.EnableSparseVector; InsertAssets rejects a batch that
+// sets it on some assets but not others.
+type AssetVector struct {
+	AssetID      string
+	Vector       []float32
+	SparseVector entity.SparseEmbedding
+}
+
+// AssetHit is a single result from SearchAssets.
+type AssetHit struct {
+	AssetID string
+	Score   float32
+}
+
+// EnsureIndex creates an index on the "vector" field of the asset
+// collection if one does not already exist.
+func (m *MilvusClient) EnsureIndex(ctx context.Context, params IndexParams) error {
+	m.assetMetricType = params.MetricType
+
+	exists, err := indexExists(ctx, m.cli, assetCollectionName, "vector")
+	if err != nil {
+		return fmt.Errorf("check asset vector index: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	idx := entity.NewGenericIndex("asset_vector_index", params.IndexType, params.Params)
+
+	if err := m.cli.CreateIndex(ctx, assetCollectionName, "vector", idx, false); err != nil {
+		return fmt.Errorf("create asset vector index: %w", err)
+	}
+
+	return nil
+}
+
+// indexExists reports whether field already has an index, using
+// DescribeIndex since the SDK has no HasIndex call. Milvus returns an
+// error for a field with no index rather than an empty result, so a
+// "not exist" error is treated as exists == false rather than a failure.
+func indexExists(ctx context.Context, cli client.Client, collName, field string) (bool, error) {
+	_, err := cli.DescribeIndex(ctx, collName, field)
+	if err == nil {
+		return true, nil
+	}
+	if strings.Contains(err.Error(), "index not exist") {
+		return false, nil
+	}
+	return false, err
+}
+
+// InsertAssets inserts a batch of asset embeddings into the asset
+// collection. When any asset in the batch sets SparseVector, every asset
+// must set it, since the collection's sparse_vector field is fixed (not
+// dynamic) and Milvus rejects an insert that omits a value for it.
+func (m *MilvusClient) InsertAssets(ctx context.Context, assets []AssetVector) error {
+	if len(assets) == 0 {
+		return nil
+	}
+
+	assetIDs := make([]string, len(assets))
+	vectors := make([][]float32, len(assets))
+	sparseVectors := make([]entity.SparseEmbedding, len(assets))
+	hasSparse := false
+	for i, a := range assets {
+		assetIDs[i] = a.AssetID
+		vectors[i] = a.Vector
+		sparseVectors[i] = a.SparseVector
+		if a.SparseVector != nil {
+			hasSparse = true
+		}
+	}
+
+	columns := []entity.Column{
+		entity.NewColumnVarChar("asset_id", assetIDs),
+		entity.NewColumnFloatVector("vector", vectorDim, vectors),
+	}
+
+	if hasSparse {
+		for i, sv := range sparseVectors {
+			if sv == nil {
+				return fmt.Errorf("insert asset vectors: asset %q is missing SparseVector", assetIDs[i])
+			}
+		}
+		columns = append(columns, entity.NewColumnSparseVector(sparseVectorFieldName, sparseVectors))
+	}
+
+	if _, err := m.cli.Insert(ctx, assetCollectionName, "", columns...); err != nil {
+		return fmt.Errorf("insert asset vectors: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAssetByID removes the embeddings for the given asset IDs from the
+// asset collection.
+func (m *MilvusClient) DeleteAssetByID(ctx context.Context, assetIDs []string) error {
+	if len(assetIDs) == 0 {
+		return nil
+	}
+
+	expr := buildInExpr("asset_id", assetIDs)
+	if err := m.cli.Delete(ctx, assetCollectionName, "", expr); err != nil {
+		return fmt.Errorf("delete asset vectors: %w", err)
+	}
+
+	return nil
+}
+
+// SearchAssets runs a vector similarity search against the asset
+// collection and returns the top-k closest assets, optionally narrowed by
+// a boolean filter expression.
+func (m *MilvusClient) SearchAssets(ctx context.Context, queryVec []float32, topK int, filter string) ([]AssetHit, error) {
+	searchParam, err := entity.NewIndexHNSWSearchParam(searchEf)
+	if err != nil {
+		return nil, fmt.Errorf("build asset search params: %w", err)
+	}
+
+	results, err := m.cli.Search(ctx, assetCollectionName, nil, filter, []string{"asset_id"},
+		[]entity.Vector{entity.FloatVector(queryVec)}, "vector", m.assetMetricType, topK, searchParam)
+	if err != nil {
+		return nil, fmt.Errorf("search asset vectors: %w", err)
+	}
+
+	var hits []AssetHit
+	for _, res := range results {
+		assetIDCol, ok := res.Fields.GetColumn("asset_id").(*entity.ColumnVarChar)
+		if !ok {
+			return nil, fmt.Errorf("search asset vectors: asset_id column missing or wrong type")
+		}
+
+		for i := 0; i < res.ResultCount; i++ {
+			hits = append(hits, AssetHit{
+				AssetID: assetIDCol.Data()[i],
+				Score:   res.Scores[i],
+			})
+		}
+	}
+
+	return hits, nil
+}
+
+func buildInExpr(field string, values []string) string {
+	expr := field + " in ["
+	for i, v := range values {
+		if i > 0 {
+			expr += ", "
+		}
+		expr += fmt.Sprintf("%q", v)
+	}
+	expr += "]"
+	return expr
+}
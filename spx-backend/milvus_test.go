@@ -0,0 +1,103 @@
+package spx_backend
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	tcmilvus "github.com/testcontainers/testcontainers-go/modules/milvus"
+)
+
+var sharedMilvusClient *MilvusClient
+
+// TestMain starts a single Milvus container for the whole package and
+// shares one MilvusClient across subtests, so each test doesn't pay the
+// cost of its own container. Outside CI, a missing Docker daemon is
+// expected and tests skip silently; in CI a failure here means the
+// environment is broken, so it fails the build instead of reporting zero
+// tests run as a pass.
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+// runTests does the actual container/client setup and teardown around
+// m.Run(). It exists separately from TestMain because os.Exit does not run
+// deferred functions, so the container and client must be torn down here,
+// before returning, rather than via defer in TestMain itself.
+func runTests(m *testing.M) int {
+	ctx := context.Background()
+	inCI := os.Getenv("CI") != ""
+
+	container, err := tcmilvus.Run(ctx, "milvusdb/milvus:v2.4.6")
+	if err != nil {
+		if inCI {
+			log.Fatalf("start Milvus container: %v", err)
+		}
+		return m.Run()
+	}
+	defer func() { _ = container.Terminate(ctx) }()
+
+	connStr, err := container.ConnectionString(ctx)
+	if err != nil {
+		if inCI {
+			log.Fatalf("get Milvus connection string: %v", err)
+		}
+		return m.Run()
+	}
+
+	cli, err := NewMilvusClient(ctx, MilvusConfig{Address: connStr})
+	if err != nil {
+		if inCI {
+			log.Fatalf("connect to Milvus container: %v", err)
+		}
+		return m.Run()
+	}
+	defer cli.Close()
+
+	sharedMilvusClient = cli
+
+	return m.Run()
+}
+
+func TestMilvusClient_CreateSearchDrop(t *testing.T) {
+	if sharedMilvusClient == nil {
+		t.Skip("docker not available, skipping Milvus integration test")
+	}
+	ctx := context.Background()
+	cli := sharedMilvusClient
+
+	if err := cli.NewCollection(ctx, CollectionOptions{ShardsNum: 1, ReplicaNumber: 1}, IndexParams{
+		MetricType: entity.L2,
+		IndexType:  entity.IvfFlat,
+		Params:     map[string]string{"nlist": "128"},
+	}); err != nil {
+		t.Fatalf("NewCollection: %v", err)
+	}
+	defer func() {
+		if err := cli.DeleteAssetByID(ctx, []string{"asset-1", "asset-2"}); err != nil {
+			t.Errorf("DeleteAssetByID: %v", err)
+		}
+		if err := cli.cli.DropCollection(ctx, assetCollectionName); err != nil {
+			t.Errorf("DropCollection: %v", err)
+		}
+		cli.cache.Reset()
+	}()
+
+	assets := []AssetVector{
+		{AssetID: "asset-1", Vector: make([]float32, vectorDim)},
+		{AssetID: "asset-2", Vector: make([]float32, vectorDim)},
+	}
+	if err := cli.InsertAssets(ctx, assets); err != nil {
+		t.Fatalf("InsertAssets: %v", err)
+	}
+
+	hits, err := cli.SearchAssets(ctx, make([]float32, vectorDim), 2, "")
+	if err != nil {
+		t.Fatalf("SearchAssets: %v", err)
+	}
+	if len(hits) == 0 {
+		t.Fatal("SearchAssets: expected at least one hit")
+	}
+}